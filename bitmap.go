@@ -0,0 +1,177 @@
+// Package bitmap provides access to the GBA's three bitmap display modes
+// (3, 4, and 5), a peer of the tiles package's Mode 0 tile rendering.
+//
+// Unlike Mode 0, these modes write pixels directly into a BG2 frame
+// buffer in VRAM rather than through a tilemap:
+//
+//	Mode 3: 240x160, 15-bit BGR color, one page (no flipping).
+//	Mode 4: 240x160, 8-bit palette index (background palette RAM), two pages.
+//	Mode 5: 160x128, 15-bit BGR color, two pages.
+//
+// Modes 4 and 5 support page flipping: draw into the back buffer (the page
+// not selected by ConfigureBitmap's page argument, or the page FlipPage
+// returned) while the other page is being scanned out, then call FlipPage
+// to present it and get a new, now-offscreen, back buffer to draw the next
+// frame into.
+//
+// Register layouts based on GBATEK: https://problemkaputt.de/gbatek.htm#gbalcdvideocontroller
+//
+// Example usage:
+//
+//	bitmap.ConfigureBitmap(4, 0)
+//	bitmap.SetPaletteColor(0, 1, bitmap.RGB(31, 0, 0))
+//	bitmap.FillRect(0, 0, 240, 160, 1)
+//	bitmap.FlipPage()
+package tinygba
+
+import "device/gba"
+
+// bitmap tracks the active mode and which physical page is on screen, so
+// PutPixel/FillRect/Blit/Line can always target the offscreen buffer.
+var bitmap struct {
+	mode        uint8
+	displayPage uint8
+}
+
+// memFrame4Or5Page1 is the VRAM offset of the second page in Mode 4/5.
+const memFrame4Or5Page1 uintptr = 0xA000
+
+// ConfigureBitmap sets DISPCNT to the given bitmap mode (3, 4, or 5),
+// enables BG2 (the bitmap layer), and selects the initially displayed
+// page (0 or 1; ignored in Mode 3, which has only one page).
+func ConfigureBitmap(mode uint8, page uint8) {
+	bitmap.mode = mode
+	bitmap.displayPage = page
+
+	bits := uint16(mode) | (1 << 10) // bits 0-2: mode, bit 10: BG2 enable
+	if page != 0 {
+		bits |= 1 << 4 // bit 4: display frame select
+	}
+	gba.DISP.DISPCNT.Set(bits)
+}
+
+// FlipPage presents the buffer currently being drawn into and returns the
+// index of the new back buffer (the page that was on screen until now).
+func FlipPage() uint8 {
+	bitmap.displayPage ^= 1
+
+	bits := gba.DISP.DISPCNT.Get()
+	bits ^= 1 << 4
+	gba.DISP.DISPCNT.Set(bits)
+
+	return bitmap.displayPage ^ 1
+}
+
+// backBufferBase returns the VRAM address of the buffer safe to draw
+// into: in Mode 3 there is only one buffer; in Mode 4/5 it's whichever
+// page is not currently being scanned out.
+func backBufferBase() uintptr {
+	if bitmap.mode == 3 || bitmap.displayPage != 0 {
+		return memVRAM
+	}
+	return memVRAM + memFrame4Or5Page1
+}
+
+// frameWidth returns the pixel width of the active bitmap mode.
+func frameWidth() int {
+	if bitmap.mode == 5 {
+		return 160
+	}
+	return 240
+}
+
+// PutPixel writes a 15-bit BGR color directly into the Mode 3/5 frame
+// buffer at (x, y).
+func PutPixel(x, y int, color uint16) {
+	addr := backBufferBase() + uintptr(y*frameWidth()+x)*2
+	mem16(addr).Set(color)
+}
+
+// PutPixel8 writes an 8-bit background palette index into the Mode 4
+// frame buffer at (x, y). Two pixels share each 16-bit VRAM halfword, so
+// this performs a read-modify-write of the untouched neighbor pixel.
+func PutPixel8(x, y int, index uint8) {
+	offset := y*frameWidth() + x
+	addr := backBufferBase() + uintptr(offset/2)*2
+	reg := mem16(addr)
+	val := reg.Get()
+	if offset&1 == 0 {
+		val = (val &^ 0x00FF) | uint16(index)
+	} else {
+		val = (val &^ 0xFF00) | uint16(index)<<8
+	}
+	reg.Set(val)
+}
+
+// FillRect fills a w x h rectangle at (x, y). In Mode 4, color's low
+// byte is used as a palette index; in Modes 3/5 it's a 15-bit BGR color.
+func FillRect(x, y, w, h int, color uint16) {
+	for row := 0; row < h; row++ {
+		for col := 0; col < w; col++ {
+			if bitmap.mode == 4 {
+				PutPixel8(x+col, y+row, uint8(color))
+			} else {
+				PutPixel(x+col, y+row, color)
+			}
+		}
+	}
+}
+
+// Blit copies a w x h region out of src (a srcW-wide row-major buffer,
+// indexed the same way as PutPixel/PutPixel8) to the frame buffer at
+// (x, y).
+func Blit(src []uint16, srcW, x, y, w, h int) {
+	for row := 0; row < h; row++ {
+		for col := 0; col < w; col++ {
+			v := src[row*srcW+col]
+			if bitmap.mode == 4 {
+				PutPixel8(x+col, y+row, uint8(v))
+			} else {
+				PutPixel(x+col, y+row, v)
+			}
+		}
+	}
+}
+
+// Line draws a straight line from (x0, y0) to (x1, y1) using Bresenham's
+// algorithm.
+func Line(x0, y0, x1, y1 int, color uint16) {
+	dx := abs(x1 - x0)
+	sx := 1
+	if x0 > x1 {
+		sx = -1
+	}
+	dy := -abs(y1 - y0)
+	sy := 1
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+
+	for {
+		if bitmap.mode == 4 {
+			PutPixel8(x0, y0, uint8(color))
+		} else {
+			PutPixel(x0, y0, color)
+		}
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
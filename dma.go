@@ -0,0 +1,130 @@
+// Package dma provides fast bulk transfers via DMA3, the general-purpose
+// DMA channel, for VRAM/palette uploads that would otherwise cost one
+// volatile write per halfword on the ARM7TDMI.
+//
+// Register layouts based on GBATEK: https://problemkaputt.de/gbatek.htm#gbadmatransfers
+//
+// Example usage:
+//
+//	dma.LoadPalette(0, myPalette[:])
+//	dma.DefineTiles4bpp(0, 1, myTiles[:])
+package tinygba
+
+import (
+	"runtime/volatile"
+	"unsafe"
+)
+
+// DMA3 register addresses.
+const (
+	dma3SAD   uintptr = 0x040000D4 // source address
+	dma3DAD   uintptr = 0x040000D8 // destination address
+	dma3CNT_L uintptr = 0x040000DC // word count
+	dma3CNT_H uintptr = 0x040000DE // control
+)
+
+// DMA3CNT_H control bits.
+const (
+	dmaDstFixed     uint16 = 2 << 5
+	dmaSrcFixed     uint16 = 2 << 7
+	dmaRepeat       uint16 = 1 << 9
+	dmaTransfer32   uint16 = 1 << 10
+	dmaTimingHBlank uint16 = 2 << 12
+	dmaEnable       uint16 = 1 << 15
+)
+
+// mem32 returns a pointer to a volatile 32-bit register at the given address.
+func mem32(addr uintptr) *volatile.Register32 {
+	return (*volatile.Register32)(unsafe.Pointer(addr))
+}
+
+// DMACopy16 copies count halfwords from src to dst using DMA3. Both
+// pointers advance as the transfer progresses (the normal case for bulk
+// VRAM/palette uploads); use DMAFill16 to write a single repeated value
+// instead.
+func DMACopy16(dst, src unsafe.Pointer, count uint32) {
+	if count == 0 {
+		return
+	}
+	mem32(dma3SAD).Set(uint32(uintptr(src)))
+	mem32(dma3DAD).Set(uint32(uintptr(dst)))
+	mem16(dma3CNT_L).Set(uint16(count))
+	mem16(dma3CNT_H).Set(dmaEnable)
+}
+
+// DMACopy32 copies count words from src to dst using DMA3's 32-bit
+// transfer mode.
+func DMACopy32(dst, src unsafe.Pointer, count uint32) {
+	if count == 0 {
+		return
+	}
+	mem32(dma3SAD).Set(uint32(uintptr(src)))
+	mem32(dma3DAD).Set(uint32(uintptr(dst)))
+	mem16(dma3CNT_L).Set(uint16(count))
+	mem16(dma3CNT_H).Set(dmaEnable | dmaTransfer32)
+}
+
+// DMAFill16 writes count copies of value into dst using DMA3, by pointing
+// the DMA source at a local halfword and holding the source address
+// fixed instead of incrementing it.
+func DMAFill16(dst unsafe.Pointer, value uint16, count uint32) {
+	if count == 0 {
+		return
+	}
+	src := value
+	mem32(dma3SAD).Set(uint32(uintptr(unsafe.Pointer(&src))))
+	mem32(dma3DAD).Set(uint32(uintptr(dst)))
+	mem16(dma3CNT_L).Set(uint16(count))
+	mem16(dma3CNT_H).Set(dmaEnable | dmaSrcFixed)
+}
+
+// DMAHBlankCopy16 arms DMA3 to repeat a count-halfword transfer from src
+// to dst at the start of every HBlank, until disabled by another DMA3
+// call. This is the standard building block for scanline raster effects
+// (per-row scroll, gradient backdrops): point src at a table that
+// advances one entry per scanline and dst at the register being animated
+// (e.g. BG0HOFS), with count matching the register's width.
+func DMAHBlankCopy16(dst, src unsafe.Pointer, count uint32) {
+	if count == 0 {
+		return
+	}
+	mem32(dma3SAD).Set(uint32(uintptr(src)))
+	mem32(dma3DAD).Set(uint32(uintptr(dst)))
+	mem16(dma3CNT_L).Set(uint16(count))
+	mem16(dma3CNT_H).Set(dmaEnable | dmaRepeat | dmaTimingHBlank | dmaDstFixed)
+}
+
+// DefineTiles4bpp uploads a contiguous run of 4bpp tiles into VRAM char
+// block charBlock starting at tileIndex first, via DMA.
+func DefineTiles4bpp(charBlock uint8, first uint16, tiles [][32]byte) {
+	if len(tiles) == 0 {
+		return
+	}
+	base := memVRAM + uintptr(charBlock)*16384 + uintptr(first)*32
+	DMACopy16(unsafe.Pointer(base), unsafe.Pointer(&tiles[0][0]), uint32(len(tiles)*16))
+}
+
+// SetTilesRow writes up to w tilemap entries into screen block screenBlock
+// starting at tile coordinate (x, y), via DMA. Writing stops at the end
+// of entries if it is shorter than w.
+func SetTilesRow(screenBlock uint8, x, y, w uint8, entries []uint16) {
+	n := int(w)
+	if n > len(entries) {
+		n = len(entries)
+	}
+	if n == 0 {
+		return
+	}
+	base := memVRAM + uintptr(screenBlock)*2048 + (uintptr(y)*32+uintptr(x))*2
+	DMACopy16(unsafe.Pointer(base), unsafe.Pointer(&entries[0]), uint32(n))
+}
+
+// LoadPalette uploads colors into palette RAM bank palette starting at
+// index 0, via DMA.
+func LoadPalette(palette uint8, colors []uint16) {
+	if len(colors) == 0 {
+		return
+	}
+	base := memPAL + uintptr(palette)*32
+	DMACopy16(unsafe.Pointer(base), unsafe.Pointer(&colors[0]), uint32(len(colors)))
+}
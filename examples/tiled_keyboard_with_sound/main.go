@@ -2,14 +2,34 @@ package main
 
 import (
 	"tinygo.org/x/tinygba"
+	"tinygo.org/x/tinygba/osk"
 )
 
+var kb = &osk.Keyboard{Layers: osk.DefaultLayers()}
+
+// typed accumulates what the user has entered; a real app would render it
+// somewhere (e.g. a text box drawn with a tile font) instead of discarding it.
+var typed []rune
+
 func main() {
-	InitTiles()
+	kb.Configure(osk.Config{CharBlock: 0, BgBlock: 8, FgBlock: 9, OriginX: 0, OriginY: 11, ShiftLayer: osk.LayerUpper})
 	tinygba.EnableSound(7, 7)
+
 	for {
 		tinygba.WaitForVBlank()
-		Update()
-		DrawTile()
+
+		for _, r := range kb.Update(tinygba.ReadButtons()) {
+			switch r {
+			case osk.RuneBackspace:
+				if len(typed) > 0 {
+					typed = typed[:len(typed)-1]
+				}
+			default:
+				typed = append(typed, r)
+			}
+			tinygba.PlayNote(1800, 0, 6, 57)
+		}
+
+		kb.Draw()
 	}
 }
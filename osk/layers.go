@@ -0,0 +1,184 @@
+package osk
+
+// 5x7 glyph bitmaps: each [7]uint8 is seven rows, and each uint8's lower
+// 5 bits are the row pixels (bit 4 = leftmost column, bit 0 = rightmost).
+// This is the same compact font the keyboard demo used to hand-roll.
+
+var letterGlyphs = map[rune][7]uint8{
+	'a': {14, 17, 17, 31, 17, 17, 17},
+	'b': {30, 17, 17, 30, 17, 17, 30},
+	'c': {14, 17, 16, 16, 16, 17, 14},
+	'd': {30, 17, 17, 17, 17, 17, 30},
+	'e': {31, 16, 16, 30, 16, 16, 31},
+	'f': {31, 16, 16, 30, 16, 16, 16},
+	'g': {14, 17, 16, 23, 17, 17, 14},
+	'h': {17, 17, 17, 31, 17, 17, 17},
+	'i': {31, 4, 4, 4, 4, 4, 31},
+	'j': {15, 1, 1, 1, 17, 17, 14},
+	'k': {17, 18, 20, 24, 20, 18, 17},
+	'l': {16, 16, 16, 16, 16, 16, 31},
+	'm': {17, 27, 21, 17, 17, 17, 17},
+	'n': {17, 25, 21, 19, 17, 17, 17},
+	'o': {14, 17, 17, 17, 17, 17, 14},
+	'p': {30, 17, 17, 30, 16, 16, 16},
+	'q': {14, 17, 17, 17, 21, 18, 13},
+	'r': {30, 17, 17, 30, 20, 18, 17},
+	's': {15, 16, 16, 14, 1, 1, 30},
+	't': {31, 4, 4, 4, 4, 4, 4},
+	'u': {17, 17, 17, 17, 17, 17, 14},
+	'v': {17, 17, 17, 17, 10, 10, 4},
+	'w': {17, 17, 17, 17, 21, 27, 17},
+	'x': {17, 10, 10, 4, 10, 10, 17},
+	'y': {17, 17, 10, 4, 4, 4, 4},
+	'z': {31, 1, 2, 4, 8, 16, 31},
+
+	'0': {14, 17, 19, 21, 25, 17, 14},
+	'1': {4, 12, 4, 4, 4, 4, 14},
+	'2': {14, 17, 1, 2, 4, 8, 31},
+	'3': {14, 17, 1, 6, 1, 17, 14},
+	'4': {2, 6, 10, 18, 31, 2, 2},
+	'5': {31, 16, 30, 1, 1, 17, 14},
+	'6': {6, 8, 16, 30, 17, 17, 14},
+	'7': {31, 1, 2, 4, 8, 8, 8},
+	'8': {14, 17, 17, 14, 17, 17, 14},
+	'9': {14, 17, 17, 15, 1, 2, 12},
+
+	' ':  {0, 0, 0, 0, 0, 0, 0},
+	'.':  {0, 0, 0, 0, 0, 0, 4},
+	',':  {0, 0, 0, 0, 0, 4, 8},
+	'!':  {4, 4, 4, 4, 4, 0, 4},
+	'?':  {14, 17, 1, 2, 4, 0, 4},
+	'-':  {0, 0, 0, 14, 0, 0, 0},
+	':':  {0, 4, 0, 0, 0, 4, 0},
+	';':  {0, 4, 0, 0, 0, 4, 8},
+	'\'': {4, 4, 0, 0, 0, 0, 0},
+}
+
+// glyphFor looks up the 5x7 bitmap for r. Upper-case letters fall back to
+// their lower-case bitmap: the shipped font has no case distinction, so
+// the "uppercase" default layer only changes which rune is emitted, not
+// how the key is drawn.
+func glyphFor(r rune) ([7]uint8, bool) {
+	if g, ok := letterGlyphs[r]; ok {
+		return g, true
+	}
+	if r >= 'A' && r <= 'Z' {
+		g, ok := letterGlyphs[r-'A'+'a']
+		return g, ok
+	}
+	return [7]uint8{}, false
+}
+
+// glyphQuarterTiles splits a centered 5x7 glyph across the four 8x8 tiles
+// of a 2x2 key block, returning (top-left, top-right, bottom-left, bottom-right).
+func glyphQuarterTiles(rows [7]uint8) (tl, tr, bl, br [32]byte) {
+	for gr := 0; gr < 7; gr++ {
+		bits := rows[gr]
+		var g [5]uint8
+		for i := range g {
+			if (bits>>(4-uint(i)))&1 != 0 {
+				g[i] = palLetter
+			}
+		}
+		var tileRow int
+		if gr < 4 {
+			tileRow = 4 + gr
+		} else {
+			tileRow = gr - 4
+		}
+		base := tileRow * 4
+		if gr < 4 {
+			tl[base+2] = 0 | (g[0] << 4)
+			tl[base+3] = g[1] | (g[2] << 4)
+			tr[base+0] = g[3] | (g[4] << 4)
+		} else {
+			bl[base+2] = 0 | (g[0] << 4)
+			bl[base+3] = g[1] | (g[2] << 4)
+			br[base+0] = g[3] | (g[4] << 4)
+		}
+	}
+	return
+}
+
+// Layer indices used by DefaultLayers.
+const (
+	LayerLower = iota
+	LayerUpper
+	LayerSymbols
+	LayerNumbers
+)
+
+// emitRow builds a Row of plain Emit keys from label runes.
+func emitRow(runes string) Row {
+	row := make(Row, 0, len(runes))
+	for _, r := range runes {
+		row = append(row, Key{Label: string(r), Rune: r, Action: Emit(r)})
+	}
+	return row
+}
+
+// padRow appends blank (space) keys to row until it has n keys, so every
+// row of a layer can be made to match the grid shape DefaultLayers
+// documents even when its source string is shorter.
+func padRow(row Row, n int) Row {
+	for len(row) < n {
+		row = append(row, Key{Label: "Space", Rune: ' ', Action: Emit(' ')})
+	}
+	return row
+}
+
+// functionRow is the bottom row shared by every default layer: Shift,
+// Backspace, space, a sticky toggle to the number layer, a momentary hop
+// to the symbol layer, and Enter.
+func functionRow() Row {
+	return Row{
+		{Label: "Shift", Action: Shift()},
+		{Label: "Bksp", Action: Backspace()},
+		{Label: "Space", Rune: ' ', Action: Emit(' ')},
+		{Label: "123", Action: Toggle(LayerNumbers)},
+		{Label: "#+=", Action: Momentary(LayerSymbols)},
+		{Label: "Enter", Action: Enter()},
+	}
+}
+
+// DefaultLayers returns the shipped US-QWERTY layer set: lowercase,
+// uppercase, symbols, and numbers, all sharing the same 4-row grid shape
+// (10/9/7/6 keys) so switching layers never moves a key on screen.
+func DefaultLayers() [][]Row {
+	lower := []Row{
+		emitRow("qwertyuiop"),
+		emitRow("asdfghjkl"),
+		emitRow("zxcvbnm"),
+		functionRow(),
+	}
+
+	upper := make([]Row, len(lower))
+	for i, row := range lower[:3] {
+		upperRow := make(Row, len(row))
+		for j, key := range row {
+			r := key.Rune
+			if r >= 'a' && r <= 'z' {
+				r -= 'a' - 'A'
+			}
+			upperRow[j] = Key{Label: string(r), Rune: r, Action: Emit(r)}
+		}
+		upper[i] = upperRow
+	}
+	upper[3] = functionRow()
+
+	symbols := []Row{
+		padRow(emitRow("!?.,;:-'"), 10),
+		padRow(emitRow(".,!?;:-'"), 9),
+		padRow(emitRow(",.!?-"), 7),
+		functionRow(),
+	}
+
+	numbers := []Row{
+		emitRow("1234567890"),
+		emitRow("123456789"),
+		emitRow("1234567"),
+		functionRow(),
+	}
+
+	return [][]Row{LayerLower: lower, LayerUpper: upper, LayerSymbols: symbols, LayerNumbers: numbers}
+}
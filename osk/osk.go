@@ -0,0 +1,435 @@
+// Package osk is a reusable on-screen keyboard for the GBA, built on top
+// of tinygba's Mode 0 tile engine. It owns the tile/palette state that
+// used to be inlined in the tiled_keyboard_with_sound example: VRAM tile
+// definitions, the tilemap writes for key backgrounds and letter glyphs,
+// and D-pad/A-button navigation.
+//
+// Keys are organized into QMK-style layers: a Keyboard holds
+// Layers [][]Row. The shipped DefaultLayers all share the same grid shape
+// (row count and per-row key count), so switching between them only
+// changes what each key does and displays, never where it sits on screen;
+// custom layers may have other shapes, and the selection is clamped into
+// the new layer on every switch. A Key's Action
+// decides what happens when it is pressed: Emit a rune, Momentary or
+// Toggle to another layer, Shift between two layers, Backspace, Enter,
+// or a user-supplied callback via Custom.
+//
+// Example usage:
+//
+//	kb := &osk.Keyboard{Layers: osk.DefaultLayers()}
+//	kb.Configure(osk.Config{CharBlock: 0, BgBlock: 8, FgBlock: 9, ShiftLayer: 1})
+//	for {
+//		tinygba.WaitForVBlank()
+//		for _, r := range kb.Update(tinygba.ReadButtons()) {
+//			typed = append(typed, r)
+//		}
+//		kb.Draw()
+//	}
+package osk
+
+import (
+	"tinygo.org/x/tinygba"
+)
+
+// actionKind selects which effect a Key's Action has when pressed.
+type actionKind uint8
+
+const (
+	kindEmit actionKind = iota
+	kindMomentary
+	kindToggle
+	kindShift
+	kindBackspace
+	kindEnter
+	kindCustom
+)
+
+// Action describes what happens when a Key is pressed. Build one with
+// Emit, Momentary, Toggle, Shift, Backspace, Enter, or Custom.
+type Action struct {
+	kind  actionKind
+	r     rune
+	layer int
+	fn    func()
+}
+
+// Emit returns an Action that appends r to Update's output.
+func Emit(r rune) Action { return Action{kind: kindEmit, r: r} }
+
+// Momentary returns an Action that switches to layer while the key is
+// held, and switches back as soon as it is released.
+func Momentary(layer int) Action { return Action{kind: kindMomentary, layer: layer} }
+
+// Toggle returns an Action that switches to layer, or back to layer 0
+// if layer is already active.
+func Toggle(layer int) Action { return Action{kind: kindToggle, layer: layer} }
+
+// Shift returns an Action that toggles between layer 0 and the
+// Keyboard's configured Config.ShiftLayer.
+func Shift() Action { return Action{kind: kindShift} }
+
+// Backspace returns an Action that appends RuneBackspace to Update's output.
+func Backspace() Action { return Action{kind: kindBackspace} }
+
+// Enter returns an Action that appends RuneEnter to Update's output.
+func Enter() Action { return Action{kind: kindEnter} }
+
+// Custom returns an Action that calls fn directly instead of emitting a rune.
+func Custom(fn func()) Action { return Action{kind: kindCustom, fn: fn} }
+
+// Sentinel runes appended to Update's output by Backspace and Enter keys,
+// for callers that accumulate typed text into a buffer.
+const (
+	RuneBackspace rune = '\b'
+	RuneEnter     rune = '\n'
+)
+
+// Key is one button on the keyboard.
+type Key struct {
+	Label  string // short name, unused by osk itself but handy for debugging/custom rendering
+	Rune   rune   // glyph drawn on the key, and the rune emitted by a plain Emit action
+	Action Action
+}
+
+// Row is one row of keys.
+type Row []Key
+
+// Palette is the set of palette-0 colors used to render one layer: the
+// normal and highlighted key background, and the letter glyph color.
+type Palette struct {
+	KeyNormal    uint16
+	KeyHighlight uint16
+	Letter       uint16
+}
+
+// Config positions the keyboard in VRAM.
+type Config struct {
+	CharBlock uint8 // VRAM char block (0-3) for key background and glyph tiles
+	BgBlock   uint8 // screen block for key background tiles (back layer)
+	FgBlock   uint8 // screen block for letter glyph tiles (front layer)
+	OriginX   uint8 // left edge of the 30-tile-wide span each key row is centered in
+	OriginY   uint8 // topmost tile row of the keyboard
+
+	// ShiftLayer is the layer index the Shift action toggles to/from
+	// layer 0. Set to -1 to disable Shift entirely.
+	ShiftLayer int
+}
+
+// VRAM tile indices shared by every layer: only the two solid-color
+// background tiles are fixed, since per-layer coloring comes from
+// palette swaps, not new tiles.
+const (
+	tileKeyNormal uint16 = 1
+	tileKeySelect uint16 = 2
+	glyphTileBase uint16 = 10
+)
+
+// Palette 0 indices used by the solid background tiles and glyph pixels.
+const (
+	palKeyNormal = 1
+	palKeyHL     = 2
+	palLetter    = 3
+)
+
+// Keyboard renders and drives one on-screen keyboard.
+type Keyboard struct {
+	Layers   [][]Row
+	Palettes []Palette // one per layer; a nil or short entry falls back to DefaultPalette
+
+	cfg    Config
+	active int
+
+	selRow, selCol     int
+	drawnRow, drawnCol int
+	drawnLayer         int
+
+	glyphTile map[rune]uint16
+
+	prevButtons   uint16
+	inMomentary   bool
+	momentaryFrom int
+}
+
+// DefaultPalette is used for any layer without an explicit Palette.
+var DefaultPalette = Palette{
+	KeyNormal:    tinygba.RGB(12, 12, 12),
+	KeyHighlight: tinygba.RGB(14, 8, 20),
+	Letter:       tinygba.RGB(31, 31, 31),
+}
+
+// Configure lays out the keyboard's VRAM tiles and tilemap and prepares
+// it to receive Update calls. Call once at startup, in place of the
+// inlined InitTiles the example used to have; it selects Mode 0 itself
+// via tinygba.ConfigureLayers.
+func (kb *Keyboard) Configure(cfg Config) {
+	kb.cfg = cfg
+	kb.active = 0
+	kb.selRow, kb.selCol = 0, 0
+	kb.drawnRow, kb.drawnCol, kb.drawnLayer = -1, -1, -1
+	kb.momentaryFrom = -1
+
+	tinygba.ConfigureLayers(tinygba.Layer0, tinygba.Layer1)
+	tinygba.SetupLayer(tinygba.Layer1, cfg.CharBlock, cfg.BgBlock, tinygba.Colors16, tinygba.Size32x32, 1)
+	tinygba.SetupLayer(tinygba.Layer0, cfg.CharBlock, cfg.FgBlock, tinygba.Colors16, tinygba.Size32x32, 0)
+	tinygba.SetScroll(tinygba.Layer0, 0, 0)
+	tinygba.SetScroll(tinygba.Layer1, 0, 0)
+
+	kb.applyPalette(0)
+	kb.defineBackgroundTiles()
+	kb.defineGlyphTiles()
+
+	tinygba.FillTiled(cfg.BgBlock, 0, 0, 30, 20, 0, 0)
+	tinygba.FillTiled(cfg.FgBlock, 0, 0, 30, 20, 0, 0)
+
+	kb.drawLayer()
+}
+
+// paletteFor returns the configured Palette for layer, or DefaultPalette.
+func (kb *Keyboard) paletteFor(layer int) Palette {
+	if layer < len(kb.Palettes) {
+		return kb.Palettes[layer]
+	}
+	return DefaultPalette
+}
+
+func (kb *Keyboard) applyPalette(layer int) {
+	p := kb.paletteFor(layer)
+	tinygba.SetPaletteColor(0, palKeyNormal, p.KeyNormal)
+	tinygba.SetPaletteColor(0, palKeyHL, p.KeyHighlight)
+	tinygba.SetPaletteColor(0, palLetter, p.Letter)
+}
+
+// defineBackgroundTiles writes the two solid-color key tiles. They are
+// reused, unmodified, by every layer; only the palette entries they
+// point at change on layer switch.
+func (kb *Keyboard) defineBackgroundTiles() {
+	var solid [32]byte
+	for i := range solid {
+		solid[i] = palKeyNormal | (palKeyNormal << 4)
+	}
+	tinygba.DefineTile4bpp(kb.cfg.CharBlock, tileKeyNormal, solid)
+
+	for i := range solid {
+		solid[i] = palKeyHL | (palKeyHL << 4)
+	}
+	tinygba.DefineTile4bpp(kb.cfg.CharBlock, tileKeySelect, solid)
+}
+
+// defineGlyphTiles uploads one set of quarter-tiles per distinct rune
+// used across every layer, and records where each landed in kb.glyphTile.
+func (kb *Keyboard) defineGlyphTiles() {
+	kb.glyphTile = make(map[rune]uint16)
+	next := glyphTileBase
+	for _, layer := range kb.Layers {
+		for _, row := range layer {
+			for _, key := range row {
+				if key.Rune == 0 {
+					continue
+				}
+				if _, ok := kb.glyphTile[key.Rune]; ok {
+					continue
+				}
+				bitmap, ok := glyphFor(key.Rune)
+				if !ok {
+					continue
+				}
+				tl, tr, bl, br := glyphQuarterTiles(bitmap)
+				tinygba.DefineTile4bpp(kb.cfg.CharBlock, next+0, tl)
+				tinygba.DefineTile4bpp(kb.cfg.CharBlock, next+1, tr)
+				tinygba.DefineTile4bpp(kb.cfg.CharBlock, next+2, bl)
+				tinygba.DefineTile4bpp(kb.cfg.CharBlock, next+3, br)
+				kb.glyphTile[key.Rune] = next
+				next += 4
+			}
+		}
+	}
+}
+
+// keyTileX/keyTileY place each key as a 2x2 tile block, packed left to
+// right with no gap. Each row is centered independently within the
+// 30-tile-wide span starting at Config.OriginX, so rows of different
+// lengths (e.g. the 10/9/7/6-key rows of DefaultLayers) all look centered.
+func (kb *Keyboard) keyTileX(row, col int) uint8 {
+	rowLen := len(kb.Layers[kb.active][row])
+	return kb.cfg.OriginX + uint8(15-rowLen+col*2)
+}
+
+func (kb *Keyboard) keyTileY(row int) uint8 {
+	return kb.cfg.OriginY + uint8(row*2)
+}
+
+// setKeyBg writes the 2x2 BG1 background tiles for one key.
+func (kb *Keyboard) setKeyBg(row, col int, tile uint16) {
+	x, y := kb.keyTileX(row, col), kb.keyTileY(row)
+	tinygba.SetTile(kb.cfg.BgBlock, x, y, tile, 0, false, false)
+	tinygba.SetTile(kb.cfg.BgBlock, x+1, y, tile, 0, false, false)
+	tinygba.SetTile(kb.cfg.BgBlock, x, y+1, tile, 0, false, false)
+	tinygba.SetTile(kb.cfg.BgBlock, x+1, y+1, tile, 0, false, false)
+}
+
+// setKeyGlyph writes the four quarter-tiles for r into BG0 at (row, col),
+// or clears them if r has no glyph.
+func (kb *Keyboard) setKeyGlyph(row, col int, r rune) {
+	x, y := kb.keyTileX(row, col), kb.keyTileY(row)
+	base, ok := kb.glyphTile[r]
+	if !ok {
+		tinygba.SetTile(kb.cfg.FgBlock, x, y, 0, 0, false, false)
+		tinygba.SetTile(kb.cfg.FgBlock, x+1, y, 0, 0, false, false)
+		tinygba.SetTile(kb.cfg.FgBlock, x, y+1, 0, 0, false, false)
+		tinygba.SetTile(kb.cfg.FgBlock, x+1, y+1, 0, 0, false, false)
+		return
+	}
+	tinygba.SetTile(kb.cfg.FgBlock, x, y, base+0, 0, false, false)
+	tinygba.SetTile(kb.cfg.FgBlock, x+1, y, base+1, 0, false, false)
+	tinygba.SetTile(kb.cfg.FgBlock, x, y+1, base+2, 0, false, false)
+	tinygba.SetTile(kb.cfg.FgBlock, x+1, y+1, base+3, 0, false, false)
+}
+
+// drawLayer redraws every key background and glyph for the active layer,
+// then highlights the current selection. Called on Configure and on
+// every layer change.
+func (kb *Keyboard) drawLayer() {
+	layer := kb.Layers[kb.active]
+	for row, rowKeys := range layer {
+		for col, key := range rowKeys {
+			kb.setKeyBg(row, col, tileKeyNormal)
+			kb.setKeyGlyph(row, col, key.Rune)
+		}
+	}
+	kb.setKeyBg(kb.selRow, kb.selCol, tileKeySelect)
+	kb.drawnRow, kb.drawnCol, kb.drawnLayer = kb.selRow, kb.selCol, kb.active
+}
+
+// Draw repaints only what changed since the last call: the highlighted
+// key, or the whole layer if the active layer changed. Call once per
+// frame, any time after Update.
+func (kb *Keyboard) Draw() {
+	if kb.active != kb.drawnLayer {
+		kb.applyPalette(kb.active)
+		kb.drawLayer()
+		return
+	}
+	if kb.selRow == kb.drawnRow && kb.selCol == kb.drawnCol {
+		return
+	}
+	kb.setKeyBg(kb.drawnRow, kb.drawnCol, tileKeyNormal)
+	kb.setKeyBg(kb.selRow, kb.selCol, tileKeySelect)
+	kb.drawnRow, kb.drawnCol = kb.selRow, kb.selCol
+}
+
+func justPressed(button tinygba.Button, curr, prev uint16) bool {
+	return button.IsPushed(curr) && !button.IsPushed(prev)
+}
+
+// Update advances keyboard navigation by one frame and returns any runes
+// emitted by an activated key (zero or one rune, in practice). Call once
+// per frame, typically right after WaitForVBlank, before Draw.
+func (kb *Keyboard) Update(buttons uint16) []rune {
+	curr := buttons
+	defer func() { kb.prevButtons = curr }()
+
+	if kb.inMomentary && !tinygba.ButtonA.IsPushed(curr) {
+		kb.active = kb.momentaryFrom
+		kb.inMomentary = false
+		kb.momentaryFrom = -1
+		kb.clampSelection()
+	}
+
+	row := kb.Layers[kb.active]
+
+	switch {
+	case justPressed(tinygba.ButtonRight, curr, kb.prevButtons):
+		kb.selCol++
+		if kb.selCol >= len(row[kb.selRow]) {
+			kb.selCol = 0
+		}
+	case justPressed(tinygba.ButtonLeft, curr, kb.prevButtons):
+		kb.selCol--
+		if kb.selCol < 0 {
+			kb.selCol = len(row[kb.selRow]) - 1
+		}
+	case justPressed(tinygba.ButtonDown, curr, kb.prevButtons):
+		kb.selRow++
+		if kb.selRow >= len(row) {
+			kb.selRow = 0
+		}
+		kb.clampCol(row)
+	case justPressed(tinygba.ButtonUp, curr, kb.prevButtons):
+		kb.selRow--
+		if kb.selRow < 0 {
+			kb.selRow = len(row) - 1
+		}
+		kb.clampCol(row)
+	}
+
+	if !justPressed(tinygba.ButtonA, curr, kb.prevButtons) {
+		return nil
+	}
+	return kb.activate(row[kb.selRow][kb.selCol].Action)
+}
+
+func (kb *Keyboard) clampCol(row []Row) {
+	if kb.selCol >= len(row[kb.selRow]) {
+		kb.selCol = len(row[kb.selRow]) - 1
+	}
+}
+
+// clampSelection clamps selRow/selCol into the bounds of the active
+// layer. Call after every kb.active reassignment: layers are not
+// required to share a shape (only the shipped DefaultLayers happens to),
+// so a switch to a smaller custom layer can otherwise leave the
+// selection pointing past the end of a row or past the last row.
+func (kb *Keyboard) clampSelection() {
+	layer := kb.Layers[kb.active]
+	if kb.selRow >= len(layer) {
+		kb.selRow = len(layer) - 1
+	}
+	if kb.selRow < 0 {
+		kb.selRow = 0
+	}
+	if kb.selCol >= len(layer[kb.selRow]) {
+		kb.selCol = len(layer[kb.selRow]) - 1
+	}
+	if kb.selCol < 0 {
+		kb.selCol = 0
+	}
+}
+
+// activate runs a's effect and returns any rune it emits.
+func (kb *Keyboard) activate(a Action) []rune {
+	switch a.kind {
+	case kindEmit:
+		return []rune{a.r}
+	case kindBackspace:
+		return []rune{RuneBackspace}
+	case kindEnter:
+		return []rune{RuneEnter}
+	case kindMomentary:
+		kb.momentaryFrom = kb.active
+		kb.inMomentary = true
+		kb.active = a.layer
+		kb.clampSelection()
+	case kindToggle:
+		if kb.active == a.layer {
+			kb.active = 0
+		} else {
+			kb.active = a.layer
+		}
+		kb.clampSelection()
+	case kindShift:
+		if kb.cfg.ShiftLayer < 0 {
+			return nil
+		}
+		if kb.active == kb.cfg.ShiftLayer {
+			kb.active = 0
+		} else if kb.active == 0 {
+			kb.active = kb.cfg.ShiftLayer
+		}
+		kb.clampSelection()
+	case kindCustom:
+		if a.fn != nil {
+			a.fn()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Package psg extends the sound package with the three PSG channels not
+// covered by EnableSound/PlayNote: Channel 1 (square wave with frequency
+// sweep), Channel 3 (user-defined wavetable), and Channel 4 (LFSR noise).
+//
+// Register layouts based on GBATEK: https://problemkaputt.de/gbatek.htm#gbasoundcontroller
+//
+// Example usage:
+//
+//	tinygba.SetSweepCh1(4, 3, false) // sweep up, shift 4, ~23ms per step
+//	tinygba.PlayNoteCh1(tinygba.NoteA4, 2, 15, 30)
+//	tinygba.PlayNoteCh4(8, 60, 0, 2, false) // percussive hit
+package tinygba
+
+import "device/gba"
+
+// WaveVolume selects the fixed playback volume for Channel 3.
+type WaveVolume uint16
+
+const (
+	WaveVolumeMute    WaveVolume = 0
+	WaveVolumeFull    WaveVolume = 1
+	WaveVolumeHalf    WaveVolume = 2
+	WaveVolumeQuarter WaveVolume = 3
+)
+
+// Named note register values for Channel 1/2, two octaves (C4-B5).
+// value = 2048 - (131072 / hz), per the standard GBA frequency formula.
+// Channel 3 steps through 32 wave samples per cycle, so the same values
+// sound one octave lower there.
+const (
+	NoteC4  uint16 = 1547
+	NoteCs4 uint16 = 1575
+	NoteD4  uint16 = 1602
+	NoteDs4 uint16 = 1627
+	NoteE4  uint16 = 1650
+	NoteF4  uint16 = 1673
+	NoteFs4 uint16 = 1694
+	NoteG4  uint16 = 1714
+	NoteGs4 uint16 = 1732
+	NoteA4  uint16 = 1750
+	NoteAs4 uint16 = 1767
+	NoteB4  uint16 = 1783
+
+	NoteC5  uint16 = 1798
+	NoteCs5 uint16 = 1812
+	NoteD5  uint16 = 1825
+	NoteDs5 uint16 = 1837
+	NoteE5  uint16 = 1849
+	NoteF5  uint16 = 1860
+	NoteFs5 uint16 = 1871
+	NoteG5  uint16 = 1881
+	NoteGs5 uint16 = 1890
+	NoteA5  uint16 = 1899
+	NoteAs5 uint16 = 1907
+	NoteB5  uint16 = 1915
+)
+
+// SOUNDCNT_L per-channel output-enable bits: EnableSound (sound.go) only
+// ever routes Channel 2 to the mixer, so PlayNoteCh1/Ch3/Ch4 route
+// themselves via routeToMixer before they'd otherwise play silently.
+const (
+	mixCh1Right uint16 = 1 << 8
+	mixCh1Left  uint16 = 1 << 12
+	mixCh3Right uint16 = 1 << 10
+	mixCh3Left  uint16 = 1 << 14
+	mixCh4Right uint16 = 1 << 11
+	mixCh4Left  uint16 = 1 << 15
+)
+
+// routeToMixer ORs bits into SOUNDCNT_L, enabling a channel's output on
+// the left and/or right speaker without disturbing the master volume
+// bits or any other channel's routing.
+func routeToMixer(bits uint16) {
+	gba.SOUND.CNT_L.Set(gba.SOUND.CNT_L.Get() | bits)
+}
+
+// SetSweepCh1 configures Channel 1's frequency sweep (SOUND1CNT_L). Call
+// before PlayNoteCh1 to shape the sweep of the note that follows.
+//
+// shift: sweep shift amount (0-7; 0 disables the sweep).
+// time:  sweep step time in 1/128s units (0-7; 0 disables the sweep).
+// decrease: sweep direction (false=increase frequency, true=decrease).
+func SetSweepCh1(shift, time uint8, decrease bool) {
+	// SOUND1CNT_L (0x4000060):
+	//   Bits 0-2: sweep shift
+	//   Bit  3:   sweep direction (0=increase, 1=decrease)
+	//   Bits 4-6: sweep time
+	value := uint16(shift&0x7) | (uint16(time&0x7) << 4)
+	if decrease {
+		value |= 1 << 3
+	}
+	gba.SOUND1.CNT_L.Set(value)
+}
+
+// PlayNoteCh1 plays a tone on Sound Channel 1 (square wave + sweep).
+// Parameters match PlayNote; call SetSweepCh1 first to shape the sweep.
+func PlayNoteCh1(frequency, duty, volume uint16, duration uint8) {
+	routeToMixer(mixCh1Right | mixCh1Left)
+
+	// SOUND1CNT_H (0x4000062) - Duty/Length/Envelope, same layout as SOUND2CNT_L.
+	gba.SOUND1.CNT_H.Set(uint16(duration) | (duty << 6) | (volume << 12))
+
+	// SOUND1CNT_X (0x4000064) - Frequency/Control, same layout as SOUND2CNT_H.
+	if duration > 0 {
+		gba.SOUND1.CNT_X.Set(frequency | (1 << 14) | (1 << 15))
+	} else {
+		gba.SOUND1.CNT_X.Set(frequency | (1 << 15))
+	}
+}
+
+// PlayNoteCh3 plays the waveform currently loaded into wave RAM bank on
+// Sound Channel 3. Load a waveform first with LoadWaveform.
+//
+// bank: which 16-byte wave RAM bank to play back from (0 or 1).
+// volume: WaveVolumeMute/Full/Half/Quarter.
+// duration: sound length in (256-n)/256s units (1-255), or 0 for continuous.
+func PlayNoteCh3(bank uint8, frequency uint16, volume WaveVolume, duration uint8) {
+	routeToMixer(mixCh3Right | mixCh3Left)
+
+	// SOUND3CNT_L (0x4000070):
+	//   Bit 5: wave RAM dimension (0=one bank/32 digits, 1=two banks/64 digits)
+	//   Bit 6: wave RAM bank number played back
+	//   Bit 7: Channel 3 enable
+	bankBit := uint16(0)
+	if bank != 0 {
+		bankBit = 1 << 6
+	}
+	gba.SOUND3.CNT_L.Set(bankBit | (1 << 7))
+
+	// SOUND3CNT_H (0x4000072) - Length/Volume.
+	gba.SOUND3.CNT_H.Set(uint16(duration) | (uint16(volume) << 13))
+
+	// SOUND3CNT_X (0x4000074) - Frequency/Control, same layout as SOUND2CNT_H.
+	if duration > 0 {
+		gba.SOUND3.CNT_X.Set(frequency | (1 << 14) | (1 << 15))
+	} else {
+		gba.SOUND3.CNT_X.Set(frequency | (1 << 15))
+	}
+}
+
+// PlayNoteCh4 plays a burst of LFSR noise on Sound Channel 4, useful for
+// percussion and hit/explosion effects.
+//
+// divRatio: dividing ratio r (0-7, 0 treated as 0.5) feeding the LFSR clock.
+// shiftFreq: shift clock frequency s (0-13).
+// narrow: LFSR width (false=15-bit, true=7-bit; 7-bit gives a "snappier", more metallic tone).
+func PlayNoteCh4(volume uint16, duration uint8, divRatio, shiftFreq uint8, narrow bool) {
+	routeToMixer(mixCh4Right | mixCh4Left)
+
+	// SOUND4CNT_L (0x4000078) - Length/Envelope, same layout as SOUND2CNT_L minus duty bits.
+	gba.SOUND4.CNT_L.Set(uint16(duration&0x3F) | (volume << 12))
+
+	// SOUND4CNT_H (0x400007C):
+	//   Bits 0-2:  dividing ratio r
+	//   Bit  3:    counter width (0=15 bit, 1=7 bit)
+	//   Bits 4-7:  shift clock frequency s
+	//   Bit  14:   length flag
+	//   Bit  15:   initial/restart sound
+	value := uint16(divRatio&0x7) | (uint16(shiftFreq&0xF) << 4)
+	if narrow {
+		value |= 1 << 3
+	}
+	if duration > 0 {
+		value |= 1 << 14
+	}
+	value |= 1 << 15
+	gba.SOUND4.CNT_H.Set(value)
+}
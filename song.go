@@ -0,0 +1,122 @@
+package tinygba
+
+// NoteRest is the Event.Note sentinel meaning "no note triggers this frame"
+// (silence, or simply let the previous note ring out/decay).
+const NoteRest uint16 = 0
+
+// Event is one step in a Song track: after DeltaFrames frames have elapsed
+// since the previous event, Note is triggered on that track's channel.
+// A track triggers at most one event per TickSound call, so a DeltaFrames
+// of 0 behaves like 1.
+//
+// Duty/Volume/Duration are interpreted per-channel, matching the parameters
+// of the corresponding PlayNoteChN primitive:
+//
+//	Track 0 (Channel 1): Duty, Volume as in PlayNoteCh1.
+//	Track 1 (Channel 2): Duty, Volume as in PlayNote.
+//	Track 2 (Channel 3): Volume (0-3) as the WaveVolume passed to PlayNoteCh3;
+//	                     Note is the playback frequency; Duty is unused.
+//	Track 3 (Channel 4): Note packs divRatio (bits 0-2) and shiftFreq (bits
+//	                     3-6); Duty != 0 selects the narrow (7-bit) LFSR;
+//	                     Volume as in PlayNoteCh4.
+type Event struct {
+	DeltaFrames uint16
+	Note        uint16
+	Duty        uint8
+	Volume      uint8
+	Duration    uint8
+}
+
+// Song is a four-track note sequence, one track per PSG channel.
+type Song struct {
+	Track [4][]Event
+}
+
+// songPlayer holds the non-blocking playback cursor for the active song.
+// There is only ever one song playing at a time, matching how EnableSound
+// owns the hardware's single set of PSG channels.
+var songPlayer struct {
+	song    *Song
+	loop    bool
+	playing bool
+	cursor  [4]int
+	timer   [4]uint16
+	done    [4]bool
+}
+
+// PlaySong starts s playing from its first event on every track. Playback
+// advances only when TickSound is called, so it must be driven once per
+// frame from the caller's VBlank loop.
+func PlaySong(s *Song, loop bool) {
+	songPlayer.song = s
+	songPlayer.loop = loop
+	songPlayer.playing = s != nil
+	for track := 0; track < 4; track++ {
+		songPlayer.cursor[track] = 0
+		songPlayer.done[track] = len(s.Track[track]) == 0
+		if !songPlayer.done[track] {
+			songPlayer.timer[track] = s.Track[track][0].DeltaFrames
+		}
+	}
+}
+
+// StopSong halts playback without touching the hardware channels directly;
+// any currently-sounding notes ring out/decay per their own Duration.
+func StopSong() {
+	songPlayer.playing = false
+}
+
+// TickSound advances song playback by one frame, triggering any track
+// events whose delay has elapsed and re-arming the hardware. Call this
+// once per frame, typically right after WaitForVBlank.
+func TickSound() {
+	if !songPlayer.playing {
+		return
+	}
+	s := songPlayer.song
+	allDone := true
+	for track := 0; track < 4; track++ {
+		if songPlayer.done[track] {
+			continue
+		}
+		allDone = false
+		if songPlayer.timer[track] > 1 {
+			songPlayer.timer[track]--
+			continue
+		}
+
+		events := s.Track[track]
+		triggerEvent(track, events[songPlayer.cursor[track]])
+
+		songPlayer.cursor[track]++
+		if songPlayer.cursor[track] >= len(events) {
+			if songPlayer.loop {
+				songPlayer.cursor[track] = 0
+			} else {
+				songPlayer.done[track] = true
+				continue
+			}
+		}
+		songPlayer.timer[track] = events[songPlayer.cursor[track]].DeltaFrames
+	}
+	if allDone {
+		songPlayer.playing = false
+	}
+}
+
+// triggerEvent plays a single Event on the hardware channel for track.
+func triggerEvent(track int, e Event) {
+	if e.Note == NoteRest {
+		return
+	}
+	switch track {
+	case 0:
+		PlayNoteCh1(e.Note, uint16(e.Duty), uint16(e.Volume), e.Duration)
+	case 1:
+		PlayNote(e.Note, uint16(e.Duty), uint16(e.Volume), e.Duration)
+	case 2:
+		PlayNoteCh3(0, e.Note, WaveVolume(e.Volume&0x3), e.Duration)
+	case 3:
+		PlayNoteCh4(uint16(e.Volume), e.Duration, uint8(e.Note&0x7), uint8((e.Note>>3)&0xF), e.Duty != 0)
+	}
+}
@@ -0,0 +1,220 @@
+// Package sprites provides access to the Game Boy Advance's OBJ (sprite)
+// hardware via OAM (Object Attribute Memory).
+//
+// OBJs are independent 8x8-aligned image units layered over (or under) the
+// tile backgrounds from the tiles package. Unlike backgrounds, each OBJ has
+// its own position, size, and palette, and can be freely moved without
+// touching the tilemap.
+//
+// Register layouts based on GBATEK: https://problemkaputt.de/gbatek.htm#gbaoamobjattributes
+//
+// Example usage:
+//
+//	sprites.EnableSprites(true)
+//	sprites.DefineSpriteTile4bpp(0, cursorPixels)
+//	sprites.SetSprite(0, 100, 80, 0, 0, sprites.Shape8x8, sprites.Size8x8, 0, false, false)
+package tinygba
+
+import (
+	"device/gba"
+	"runtime/volatile"
+	"unsafe"
+)
+
+// Memory base addresses for OAM and the OBJ tile region of VRAM.
+const (
+	memOAM     uintptr = 0x07000000
+	memVRAMObj uintptr = 0x06010000
+)
+
+// Shape is the OBJ shape field (OAM attribute 0, bits 14-15).
+type Shape uint8
+
+const (
+	ShapeSquare Shape = 0
+	ShapeWide   Shape = 1
+	ShapeTall   Shape = 2
+)
+
+// Size is the OBJ size field (OAM attribute 1, bits 14-15). Combined with
+// Shape it selects one of the twelve standard OBJ dimensions.
+type Size uint8
+
+const (
+	Size0 Size = 0
+	Size1 Size = 1
+	Size2 Size = 2
+	Size3 Size = 3
+)
+
+// The twelve standard shape/size combinations and their pixel dimensions:
+//
+//	Shape8x8   Size8x8    ==  8x8
+//	Shape16x16 Size16x16  == 16x16
+//	Shape32x32 Size32x32  == 32x32
+//	Shape64x64 Size64x64  == 64x64
+//	ShapeWide16x8   Size16x8   == 16x8
+//	ShapeWide32x8   Size32x8   == 32x8
+//	ShapeWide32x16  Size32x16  == 32x16
+//	ShapeWide64x32  Size64x32  == 64x32
+//	ShapeTall8x16   Size8x16   == 8x16
+//	ShapeTall8x32   Size8x32   == 8x32
+//	ShapeTall16x32  Size16x32  == 16x32
+//	ShapeTall32x64  Size32x64  == 32x64
+const (
+	Shape8x8   = ShapeSquare
+	Shape16x16 = ShapeSquare
+	Shape32x32 = ShapeSquare
+	Shape64x64 = ShapeSquare
+
+	ShapeWide16x8  = ShapeWide
+	ShapeWide32x8  = ShapeWide
+	ShapeWide32x16 = ShapeWide
+	ShapeWide64x32 = ShapeWide
+
+	ShapeTall8x16  = ShapeTall
+	ShapeTall8x32  = ShapeTall
+	ShapeTall16x32 = ShapeTall
+	ShapeTall32x64 = ShapeTall
+)
+
+const (
+	Size8x8   = Size0
+	Size16x16 = Size1
+	Size32x32 = Size2
+	Size64x64 = Size3
+
+	Size16x8  = Size0
+	Size32x8  = Size1
+	Size32x16 = Size2
+	Size64x32 = Size3
+
+	Size8x16  = Size0
+	Size8x32  = Size1
+	Size16x32 = Size2
+	Size32x64 = Size3
+)
+
+// oamEntry mirrors the 8-byte (3x uint16 + 1 pad) hardware OAM layout for
+// one of the 128 sprite slots.
+type oamEntry struct {
+	attr0 volatile.Register16
+	attr1 volatile.Register16
+	attr2 volatile.Register16
+	_     volatile.Register16 // unused; holds affine matrix data for rotation groups
+}
+
+// oam returns a pointer to OAM entry index (0-127).
+func oam(index uint8) *oamEntry {
+	return (*oamEntry)(unsafe.Pointer(memOAM + uintptr(index)*8))
+}
+
+// EnableSprites turns on OBJ rendering and selects the tile-mapping mode.
+//
+// mapping1D: true selects 1D character mapping (tiles for a multi-tile
+// sprite are contiguous in memory); false selects 2D mapping (tiles are
+// laid out in a 32-tile-wide grid, matching the background char blocks).
+func EnableSprites(mapping1D bool) {
+	bits := gba.DISP.DISPCNT.Get()
+	bits |= 1 << 12 // OBJ enable
+	if mapping1D {
+		bits |= 1 << 6 // OBJ character VRAM mapping: 1D
+	} else {
+		bits &^= 1 << 6
+	}
+	gba.DISP.DISPCNT.Set(bits)
+}
+
+// DefineSpriteTile4bpp writes a 32-byte 4bpp tile into the OBJ tile region
+// of VRAM (0x06010000-0x06017FFF, 1024 tiles).
+func DefineSpriteTile4bpp(tileIndex uint16, pixels [32]byte) {
+	base := memVRAMObj + uintptr(tileIndex)*32
+	for i := 0; i < 16; i++ {
+		val := uint16(pixels[i*2]) | uint16(pixels[i*2+1])<<8
+		mem16(base + uintptr(i)*2).Set(val)
+	}
+}
+
+// DefineSpriteTile8bpp writes a 64-byte 8bpp tile into the OBJ tile region
+// of VRAM (512 tiles; each 8bpp tile occupies two 4bpp tile slots).
+func DefineSpriteTile8bpp(tileIndex uint16, pixels [64]byte) {
+	base := memVRAMObj + uintptr(tileIndex)*32
+	for i := 0; i < 32; i++ {
+		val := uint16(pixels[i*2]) | uint16(pixels[i*2+1])<<8
+		mem16(base + uintptr(i)*2).Set(val)
+	}
+}
+
+// SetSprite configures OAM entry index (0-127).
+//
+//   - x, y:      top-left pixel position (0-511/0-255; values wrap per GBATEK)
+//   - tile:      first tile index of the sprite graphics
+//   - palette:   0-15; SetSprite always configures the entry as 4bpp (16-color), so 8bpp tiles are not shown as 8bpp
+//   - shape, size: one of the Shape*/Size* constants above
+//   - priority:  0 (front) to 3 (back), relative to background layers
+//   - flipH, flipV: mirror the sprite horizontally/vertically
+func SetSprite(index uint8, x, y int, tile uint16, palette uint8, shape Shape, size Size, priority uint8, flipH, flipV bool) {
+	e := oam(index)
+
+	attr0 := uint16(y) & 0xFF
+	attr0 |= uint16(shape) << 14
+
+	attr1 := uint16(x) & 0x1FF
+	if flipH {
+		attr1 |= 1 << 12
+	}
+	if flipV {
+		attr1 |= 1 << 13
+	}
+	attr1 |= uint16(size) << 14
+
+	attr2 := tile & 0x3FF
+	attr2 |= uint16(priority) << 10
+	attr2 |= uint16(palette) << 12
+
+	e.attr0.Set(attr0)
+	e.attr1.Set(attr1)
+	e.attr2.Set(attr2)
+}
+
+// HideSprite disables rendering of OAM entry index by setting its OBJ
+// disable bit. It also clears the affine flag (affine entries read that
+// bit as double-size), but leaves its other attributes untouched.
+func HideSprite(index uint8) {
+	e := oam(index)
+	attr0 := e.attr0.Get()
+	attr0 &^= 1 << 8
+	attr0 |= 1 << 9
+	e.attr0.Set(attr0)
+}
+
+// SetSpriteAffine assigns OAM entry index to affine (rotation/scaling)
+// group matrixIndex (0-31) and marks the entry as affine. Use
+// SetAffineMatrix to populate the group's transformation matrix.
+func SetSpriteAffine(index uint8, matrixIndex uint8, doubleSize bool) {
+	e := oam(index)
+	attr0 := e.attr0.Get()
+	attr0 &^= 1 << 9 // clear OBJ-disable; affine entries reuse this bit for double-size
+	attr0 |= 1 << 8  // OBJ mode: affine
+	if doubleSize {
+		attr0 |= 1 << 9
+	}
+	e.attr0.Set(attr0)
+
+	attr1 := e.attr1.Get()
+	attr1 &^= 0x3E00
+	attr1 |= uint16(matrixIndex&0x1F) << 9
+	e.attr1.Set(attr1)
+}
+
+// SetAffineMatrix writes rotation/scaling group matrixIndex (0-31). The
+// four parameters are 8.8 fixed-point values forming the 2x2 matrix
+// [[pa, pb], [pc, pd]] applied to sprite texture coordinates, per GBATEK's
+// OBJ rotation/scaling parameters.
+func SetAffineMatrix(matrixIndex uint8, pa, pb, pc, pd int16) {
+	base := memOAM + uintptr(matrixIndex)*32 + 6
+	mem16(base + 0*8).Set(uint16(pa))
+	mem16(base + 1*8).Set(uint16(pb))
+	mem16(base + 2*8).Set(uint16(pc))
+	mem16(base + 3*8).Set(uint16(pd))
+}
@@ -0,0 +1,144 @@
+// Package text is a bitmap font renderer built on the tile engine from
+// the tiles package: it uploads glyph bitmaps into VRAM as tiles, then
+// writes tilemap entries to draw strings. This generalizes the glyph
+// table and quarter-tile splitter the on-screen keyboard hand-rolls for
+// its keys (see osk/layers.go) into something any HUD, dialog box, or
+// debug overlay can reuse.
+//
+// Example usage:
+//
+//	text.LoadFont(text.Font8x8, 0, 20) // char block 0, starting at tile 20
+//	tinygba.SetPaletteColor(0, 1, tinygba.RGB(31, 31, 31))
+//	text.Font8x8.DrawString(10, 2, 2, 0, "score 0")
+package text
+
+import (
+	"sort"
+
+	"tinygo.org/x/tinygba"
+)
+
+// Font is a monospaced bitmap font: every glyph occupies a GlyphWidth x
+// GlyphHeight box, encoded one row per uint32 with bit (GlyphWidth-1-x)
+// set for an on pixel at column x. Glyphs wider or taller than 8px are
+// split across multiple 8x8 tiles automatically by LoadFont.
+type Font struct {
+	GlyphWidth, GlyphHeight uint8
+	Glyphs                  map[rune][]uint32
+
+	// Baseline is the pixel row, counting down from the top of the
+	// glyph's authored rows, that the font's baseline sits on (e.g. 6 for
+	// a 7-row font with no descenders). writeGlyphTiles anchors this row
+	// to the bottom pixel row of the glyph's tile box, so glyphs shorter
+	// than a whole number of tiles get their blank padding above the
+	// baseline instead of below it.
+	Baseline uint8
+
+	// Fallback is drawn in place of any rune not found in Glyphs. Zero
+	// means draw nothing for a missing glyph: its cell keeps whatever
+	// tile it already held.
+	Fallback rune
+
+	charBlock   uint8
+	tilesWide   uint8
+	tilesHigh   uint8
+	glyphTileOf map[rune]uint16
+}
+
+// LoadFont uploads every glyph in f.Glyphs into VRAM char block
+// charBlock, starting at firstTile, and records where each glyph landed
+// so DrawString can look it up. Call once at startup, after the layer
+// that will use this font has been configured with a matching ColorMode.
+func LoadFont(f *Font, charBlock uint8, firstTile uint16) {
+	f.charBlock = charBlock
+	f.tilesWide = (f.GlyphWidth + 7) / 8
+	f.tilesHigh = (f.GlyphHeight + 7) / 8
+	tilesPerGlyph := uint16(f.tilesWide) * uint16(f.tilesHigh)
+
+	runes := make([]rune, 0, len(f.Glyphs))
+	for r := range f.Glyphs {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	f.glyphTileOf = make(map[rune]uint16, len(runes))
+	tile := firstTile
+	for _, r := range runes {
+		f.glyphTileOf[r] = tile
+		f.writeGlyphTiles(tile, f.Glyphs[r])
+		tile += tilesPerGlyph
+	}
+}
+
+// writeGlyphTiles splits one glyph's rows across f.tilesWide x f.tilesHigh
+// tiles starting at firstTile and uploads them via DefineTile4bpp. Every
+// "on" pixel is written as palette index 1; DrawString's palette argument
+// selects which hardware palette (and so which color) index 1 resolves to.
+func (f *Font) writeGlyphTiles(firstTile uint16, rows []uint32) {
+	// padTop shifts the authored rows down so Baseline lands on the last
+	// pixel row of the tile box, rather than always top-aligning row 0.
+	padTop := int(f.tilesHigh)*8 - 1 - int(f.Baseline)
+	if padTop < 0 {
+		padTop = 0
+	}
+
+	for ty := uint8(0); ty < f.tilesHigh; ty++ {
+		for tx := uint8(0); tx < f.tilesWide; tx++ {
+			var pixels [32]byte
+			for py := uint8(0); py < 8; py++ {
+				row := int(ty)*8 + int(py) - padTop
+				if row < 0 || row >= len(rows) {
+					continue
+				}
+				rowBits := rows[row]
+				for px := uint8(0); px < 8; px++ {
+					col := int(tx)*8 + int(px)
+					if col >= int(f.GlyphWidth) {
+						continue
+					}
+					if (rowBits>>(uint(f.GlyphWidth)-1-uint(col)))&1 == 0 {
+						continue
+					}
+					if px%2 == 0 {
+						pixels[py*4+px/2] |= 1
+					} else {
+						pixels[py*4+px/2] |= 1 << 4
+					}
+				}
+			}
+			tinygba.DefineTile4bpp(f.charBlock, firstTile+uint16(ty)*uint16(f.tilesWide)+uint16(tx), pixels)
+		}
+	}
+}
+
+// DrawString writes the tilemap entries to render s into screen block
+// screenBlock, starting at tile coordinate (x, y), using hardware palette
+// palette. Every glyph advances the cursor by f.tilesWide tile columns
+// regardless of its visual width, and '\n' starts a new line. s is
+// iterated as UTF-8; runes without a glyph fall back to f.Fallback, or,
+// if Fallback is zero or also missing, write nothing but still advance
+// the cursor.
+func (f *Font) DrawString(screenBlock uint8, x, y uint8, palette uint8, s string) {
+	col, row := x, y
+	for _, r := range s {
+		if r == '\n' {
+			row += f.tilesHigh
+			col = x
+			continue
+		}
+
+		tile, ok := f.glyphTileOf[r]
+		if !ok && f.Fallback != 0 {
+			tile, ok = f.glyphTileOf[f.Fallback]
+		}
+		if ok {
+			for ty := uint8(0); ty < f.tilesHigh; ty++ {
+				for tx := uint8(0); tx < f.tilesWide; tx++ {
+					t := tile + uint16(ty)*uint16(f.tilesWide) + uint16(tx)
+					tinygba.SetTile(screenBlock, col+tx, row+ty, t, palette, false, false)
+				}
+			}
+		}
+		col += f.tilesWide
+	}
+}
@@ -0,0 +1,89 @@
+package text
+
+// glyph5x7 holds the compact 5x7 font's bitmaps: lower-case letters,
+// digits, and a handful of punctuation, each row's 5 bits packed into
+// bits 4-0 of a uint32 (bit 4 = leftmost column).
+var glyph5x7 = map[rune][]uint32{
+	'a': {14, 17, 17, 31, 17, 17, 17},
+	'b': {30, 17, 17, 30, 17, 17, 30},
+	'c': {14, 17, 16, 16, 16, 17, 14},
+	'd': {30, 17, 17, 17, 17, 17, 30},
+	'e': {31, 16, 16, 30, 16, 16, 31},
+	'f': {31, 16, 16, 30, 16, 16, 16},
+	'g': {14, 17, 16, 23, 17, 17, 14},
+	'h': {17, 17, 17, 31, 17, 17, 17},
+	'i': {31, 4, 4, 4, 4, 4, 31},
+	'j': {15, 1, 1, 1, 17, 17, 14},
+	'k': {17, 18, 20, 24, 20, 18, 17},
+	'l': {16, 16, 16, 16, 16, 16, 31},
+	'm': {17, 27, 21, 17, 17, 17, 17},
+	'n': {17, 25, 21, 19, 17, 17, 17},
+	'o': {14, 17, 17, 17, 17, 17, 14},
+	'p': {30, 17, 17, 30, 16, 16, 16},
+	'q': {14, 17, 17, 17, 21, 18, 13},
+	'r': {30, 17, 17, 30, 20, 18, 17},
+	's': {15, 16, 16, 14, 1, 1, 30},
+	't': {31, 4, 4, 4, 4, 4, 4},
+	'u': {17, 17, 17, 17, 17, 17, 14},
+	'v': {17, 17, 17, 17, 10, 10, 4},
+	'w': {17, 17, 17, 17, 21, 27, 17},
+	'x': {17, 10, 10, 4, 10, 10, 17},
+	'y': {17, 17, 10, 4, 4, 4, 4},
+	'z': {31, 1, 2, 4, 8, 16, 31},
+
+	'0': {14, 17, 19, 21, 25, 17, 14},
+	'1': {4, 12, 4, 4, 4, 4, 14},
+	'2': {14, 17, 1, 2, 4, 8, 31},
+	'3': {14, 17, 1, 6, 1, 17, 14},
+	'4': {2, 6, 10, 18, 31, 2, 2},
+	'5': {31, 16, 30, 1, 1, 17, 14},
+	'6': {6, 8, 16, 30, 17, 17, 14},
+	'7': {31, 1, 2, 4, 8, 8, 8},
+	'8': {14, 17, 17, 14, 17, 17, 14},
+	'9': {14, 17, 17, 15, 1, 2, 12},
+
+	' ': {0, 0, 0, 0, 0, 0, 0},
+	'.': {0, 0, 0, 0, 0, 0, 4},
+	',': {0, 0, 0, 0, 0, 4, 8},
+	'!': {4, 4, 4, 4, 4, 0, 4},
+	'?': {14, 17, 1, 2, 4, 0, 4},
+}
+
+// Font5x7 is a compact font well suited to small UI elements like the
+// on-screen keyboard's keys.
+var Font5x7 = &Font{
+	GlyphWidth:  5,
+	GlyphHeight: 7,
+	Baseline:    6,
+	Glyphs:      glyph5x7,
+	Fallback:    '?',
+}
+
+// glyph8x8 is derived from glyph5x7: each 5-wide row is shifted two bits
+// left to center it in an 8-wide cell (a 1px left margin, 2px right).
+// The 7 rows still leave row 0 of the tile blank (Font8x8.Baseline pins
+// row 6 to the tile's bottom pixel row), giving callers a full 8x8 tile
+// per glyph while keeping the two shipped fonts visually related.
+var glyph8x8 = expand5x7To8x8(glyph5x7)
+
+func expand5x7To8x8(src map[rune][]uint32) map[rune][]uint32 {
+	out := make(map[rune][]uint32, len(src))
+	for r, rows := range src {
+		expanded := make([]uint32, len(rows))
+		for i, row := range rows {
+			expanded[i] = row << 2
+		}
+		out[r] = expanded
+	}
+	return out
+}
+
+// Font8x8 is a full-tile font: every glyph fills its own 8x8 char block
+// entry with no splitting, the simplest and fastest case for LoadFont.
+var Font8x8 = &Font{
+	GlyphWidth:  8,
+	GlyphHeight: 8,
+	Baseline:    6,
+	Glyphs:      glyph8x8,
+	Fallback:    '?',
+}
@@ -0,0 +1,97 @@
+// Package wave adds additive-synthesis waveform authoring on top of Sound
+// Channel 3's user wavetable (see PlayNoteCh3 in psg.go).
+//
+// Wave RAM holds two 16-byte banks of 32 packed 4-bit samples each, at
+// 0x04000090 (bank 0) and 0x040000A0 (bank 1). Only one bank plays at a
+// time (selected by PlayNoteCh3's bank argument / SOUND3CNT_L bit 6); the
+// other is free to rewrite. This enables a double-buffer trick for
+// clickless timbre changes: load the next instrument into the bank that
+// is *not* currently playing, then call PlayWave with that bank on the
+// next note. Never call LoadWaveform on the bank mid-note that is
+// actively being read by the hardware, or the sample stream will glitch.
+package tinygba
+
+import "math"
+
+// Wave RAM bank base addresses.
+const (
+	memWaveBank0 uintptr = 0x04000090
+	memWaveBank1 uintptr = 0x040000A0
+)
+
+// LoadWaveform writes 32 4-bit samples into wave RAM bank (0 or 1).
+// Each element of samples is a nibble (0-15); values outside that range
+// are masked.
+func LoadWaveform(bank uint8, samples [32]uint8) {
+	base := memWaveBank0
+	if bank != 0 {
+		base = memWaveBank1
+	}
+	for i := 0; i < 8; i++ {
+		b0 := samples[i*4]&0xF | (samples[i*4+1]&0xF)<<4
+		b1 := samples[i*4+2]&0xF | (samples[i*4+3]&0xF)<<4
+		mem16(base + uintptr(i)*2).Set(uint16(b0) | uint16(b1)<<8)
+	}
+}
+
+// PlayWave plays wave RAM bank on Sound Channel 3.
+//
+// volume is a linear 0-15 level, mapped down to the hardware's four-step
+// WaveVolume; duration is in (256-n)/256s units (1-255), or 0 for continuous.
+func PlayWave(bank uint8, frequency uint16, volume uint8, duration uint8) {
+	var wv WaveVolume
+	switch {
+	case volume == 0:
+		wv = WaveVolumeMute
+	case volume <= 5:
+		wv = WaveVolumeQuarter
+	case volume <= 10:
+		wv = WaveVolumeHalf
+	default:
+		wv = WaveVolumeFull
+	}
+	PlayNoteCh3(bank, frequency, wv, duration)
+}
+
+// BuildWaveform evaluates an additive-synthesis timbre from up to 16
+// harmonic amplitudes and returns it as a 32-sample waveform ready for
+// LoadWaveform.
+//
+// s[n] = sum(h[k] * sin(2*pi*k*n/32)) for n = 0..31, where h[k] is
+// harmonics[k]. The result is normalized so its peak maps to the full
+// nibble range, then quantized to 4-bit unsigned (7/8 = the zero crossing).
+func BuildWaveform(harmonics []float32) [32]uint8 {
+	numHarmonics := len(harmonics)
+	if numHarmonics > 16 {
+		numHarmonics = 16
+	}
+
+	var samples [32]float32
+	var peak float32
+	for n := 0; n < 32; n++ {
+		var s float32
+		for k := 0; k < numHarmonics; k++ {
+			s += harmonics[k] * float32(math.Sin(2*math.Pi*float64(k)*float64(n)/32))
+		}
+		samples[n] = s
+		if abs := float32(math.Abs(float64(s))); abs > peak {
+			peak = abs
+		}
+	}
+
+	var out [32]uint8
+	for n, s := range samples {
+		if peak > 0 {
+			s /= peak
+		}
+		q := int32((s+1)*7.5 + 0.5)
+		switch {
+		case q < 0:
+			q = 0
+		case q > 15:
+			q = 15
+		}
+		out[n] = uint8(q)
+	}
+	return out
+}